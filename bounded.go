@@ -0,0 +1,122 @@
+package errbuf
+
+import "math/rand"
+
+// OverflowPolicy decides what happens to a [BufferedError] created via
+// [NewBoundedErrorsBuffer] once one of its buffers reaches its configured
+// limit.
+type OverflowPolicy int
+
+const (
+	// PolicyDropNewest discards the incoming entry, keeping the buffer as
+	// it was.
+	PolicyDropNewest OverflowPolicy = iota
+	// PolicyDropOldest evicts the oldest entry to make room, turning the
+	// buffer into a ring buffer.
+	PolicyDropOldest
+	// PolicySample keeps a uniform random sample of all entries seen so
+	// far, using reservoir sampling.
+	PolicySample
+	// PolicyCoalesce collapses entries whose [error.Error] string matches
+	// an entry already in the buffer, bumping its occurrence count
+	// instead of storing a duplicate. Unlike the other policies, it applies
+	// to every entry offered to a bounded buffer, not only once the buffer
+	// is full; once full, an entry with a new message is discarded.
+	PolicyCoalesce
+)
+
+// NewBoundedErrorsBuffer creates a [BufferedError] whose errors and warnings
+// buffers are capped at maxErrors and maxWarnings respectively. A limit of 0
+// leaves the corresponding buffer unbounded. Once a buffer is full,
+// subsequent entries are handled according to policy. Use [NewErrorsBuffer]
+// for the default, fully unbounded buffer.
+func NewBoundedErrorsBuffer(maxErrors, maxWarnings int, policy OverflowPolicy, opts ...Option) *BufferedError {
+	buf := NewErrorsBuffer(opts...)
+	buf.maxErrors = maxErrors
+	buf.maxWarnings = maxWarnings
+	buf.policy = policy
+	buf.occurrences = make(map[string]int)
+
+	return buf
+}
+
+// findByMessage returns the index of the first error in sl whose Error()
+// string equals msg.
+func findByMessage(sl []error, msg string) (int, bool) {
+	for i, err := range sl {
+		if err.Error() == msg {
+			return i, true
+		}
+	}
+
+	return -1, false
+}
+
+// appendBounded appends e to dst, honoring buf.policy once dst reaches max.
+// seen is the total number of entries ever offered to dst, used by
+// [PolicySample].
+func (buf *BufferedError) appendBounded(dst []error, e *entry, max, seen int) []error {
+	if buf.policy == PolicyCoalesce {
+		if _, ok := findByMessage(dst, e.err.Error()); ok {
+			buf.occurrences[e.err.Error()]++
+
+			return dst
+		}
+
+		if len(dst) >= max {
+			buf.dropped++
+
+			return dst
+		}
+
+		buf.occurrences[e.err.Error()] = 1
+
+		return append(dst, e)
+	}
+
+	if len(dst) < max {
+		return append(dst, e)
+	}
+
+	buf.dropped++
+
+	switch buf.policy {
+	case PolicyDropOldest:
+		// Shift down and overwrite the last slot in place, rather than
+		// re-slicing dst[1:], so the evicted entry is not kept alive by
+		// the backing array of an ever-advancing slice.
+		copy(dst, dst[1:])
+		dst[len(dst)-1] = e
+
+		return dst
+	case PolicySample:
+		if j := rand.Intn(seen); j < max {
+			dst[j] = e
+		}
+
+		return dst
+	default: // PolicyDropNewest
+		return dst
+	}
+}
+
+// Dropped returns the number of entries discarded because a bounded buffer
+// was full. It is always 0 for a buffer created with [NewErrorsBuffer].
+func (buf *BufferedError) Dropped() int {
+	buf.Lock()
+	defer buf.Unlock()
+
+	return buf.dropped
+}
+
+// Occurrences returns how many times an error matching err's message was
+// offered to a buffer using [PolicyCoalesce] and either stored or collapsed
+// into the stored entry. An entry with a new message that is dropped
+// because the buffer is full is not counted. It is always 0 for any other
+// policy.
+func (buf *BufferedError) Occurrences(err error) int {
+	buf.Lock()
+	defer buf.Unlock()
+
+	return buf.occurrences[err.Error()]
+}
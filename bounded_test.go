@@ -0,0 +1,100 @@
+package errbuf
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestBoundedDropNewest(t *testing.T) {
+	buf := NewBoundedErrorsBuffer(2, 0, PolicyDropNewest)
+
+	for i := 0; i < 5; i++ {
+		buf.Add(fmt.Errorf("e%d", i))
+	}
+
+	errs, _ := buf.Split()
+	if len(errs) != 2 {
+		t.Fatalf("len(errs) = %d, want 2", len(errs))
+	}
+
+	if got := errs[0].Error(); got != "e0" {
+		t.Fatalf("errs[0] = %q, want e0 (newest entries should be the ones dropped)", got)
+	}
+
+	if buf.Dropped() != 3 {
+		t.Fatalf("Dropped() = %d, want 3", buf.Dropped())
+	}
+}
+
+func TestBoundedDropOldest(t *testing.T) {
+	buf := NewBoundedErrorsBuffer(2, 0, PolicyDropOldest)
+
+	for i := 0; i < 5; i++ {
+		buf.Add(fmt.Errorf("e%d", i))
+	}
+
+	errs, _ := buf.Split()
+	if len(errs) != 2 || errs[0].Error() != "e3" || errs[1].Error() != "e4" {
+		t.Fatalf("errs = %v, want [e3 e4] (oldest entries should be the ones dropped)", errs)
+	}
+
+	if buf.Dropped() != 3 {
+		t.Fatalf("Dropped() = %d, want 3", buf.Dropped())
+	}
+}
+
+func TestBoundedSampleKeepsMaxAndCountsDropped(t *testing.T) {
+	const max = 10
+
+	buf := NewBoundedErrorsBuffer(max, 0, PolicySample)
+
+	for i := 0; i < 100; i++ {
+		buf.Add(fmt.Errorf("e%d", i))
+	}
+
+	errs, _ := buf.Split()
+	if len(errs) != max {
+		t.Fatalf("len(errs) = %d, want %d", len(errs), max)
+	}
+
+	if buf.Dropped() != 90 {
+		t.Fatalf("Dropped() = %d, want 90", buf.Dropped())
+	}
+}
+
+func TestBoundedCoalesce(t *testing.T) {
+	buf := NewBoundedErrorsBuffer(2, 0, PolicyCoalesce)
+
+	dup := errors.New("connection reset")
+
+	buf.Add(dup)
+	buf.Add(dup)
+	buf.Add(dup)
+	buf.Add(errors.New("bad request"))
+
+	errs, _ := buf.Split()
+	if len(errs) != 2 {
+		t.Fatalf("len(errs) = %d, want 2 (duplicates should coalesce)", len(errs))
+	}
+
+	if got := buf.Occurrences(dup); got != 3 {
+		t.Fatalf("Occurrences(dup) = %d, want 3", got)
+	}
+}
+
+func TestBoundedCoalesceDropsNewUniqueOnceFull(t *testing.T) {
+	buf := NewBoundedErrorsBuffer(1, 0, PolicyCoalesce)
+
+	buf.Add(errors.New("a"))
+	buf.Add(errors.New("b"))
+
+	errs, _ := buf.Split()
+	if len(errs) != 1 || errs[0].Error() != "a" {
+		t.Fatalf("errs = %v, want [a]", errs)
+	}
+
+	if buf.Dropped() != 1 {
+		t.Fatalf("Dropped() = %d, want 1", buf.Dropped())
+	}
+}
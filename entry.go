@@ -0,0 +1,90 @@
+package errbuf
+
+import "runtime"
+
+// Severity describes how critical a buffered entry is.
+type Severity int
+
+const (
+	// SeverityError marks an entry collected through [BufferedError.Add]
+	// or [BufferedError.AddWithAttrs].
+	SeverityError Severity = iota
+	// SeverityWarning marks an entry collected through [BufferedError.Warn]
+	// or [BufferedError.WarnWithAttrs].
+	SeverityWarning
+	// SeverityInfo marks an entry that is informational only and does not
+	// belong to either the errors or the warnings buffer by default.
+	SeverityInfo
+)
+
+// String returns human readable name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	default:
+		return "unknown"
+	}
+}
+
+// Kind tags an entry with a caller defined category, e.g. a string or int
+// constant such as KindNetwork or KindValidation. The zero value means the
+// entry was not categorized. Kind is expected to hold a comparable value;
+// [FilterByKind] and [CountByKind] treat a non-comparable kind (a slice,
+// map or func) as not matching anything rather than panicking.
+type Kind any
+
+// kindEqual reports whether a and b are equal, treating a non-comparable
+// dynamic type as never equal instead of panicking.
+func kindEqual(a, b Kind) (eq bool) {
+	defer func() {
+		if recover() != nil {
+			eq = false
+		}
+	}()
+
+	return a == b
+}
+
+// countKind increments counts[k], skipping k silently if its dynamic type
+// is not comparable and therefore cannot be used as a map key.
+func countKind(counts map[Kind]int, k Kind) {
+	defer func() { recover() }()
+
+	counts[k]++
+}
+
+// Attr is a key/value pair attached to an entry, similar to [slog.Attr].
+type Attr struct {
+	Key   string
+	Value any
+}
+
+// entry wraps a single error collected into the buffer together with its
+// severity, kind and attributes. It implements the error interface so it can
+// be stored alongside plain errors and unwrapped transparently.
+type entry struct {
+	err      error
+	severity Severity
+	kind     Kind
+	attrs    []Attr
+
+	// frames holds stack frames captured when the owning [BufferedError]
+	// was created with [WithStackTrace].
+	frames []runtime.Frame
+}
+
+// Error returns the underlying error message.
+func (e *entry) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error, allowing [errors.Is] and [errors.As]
+// to traverse into it.
+func (e *entry) Unwrap() error {
+	return e.err
+}
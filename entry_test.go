@@ -0,0 +1,40 @@
+package errbuf
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFilterByKindAndCountByKind(t *testing.T) {
+	buf := NewErrorsBuffer()
+	buf.AddWithAttrs(errors.New("net down"), "net")
+	buf.AddWithAttrs(errors.New("bad input"), "validation")
+	buf.WarnWithAttrs(errors.New("net flaky"), "net")
+	buf.Add(errors.New("uncategorized"))
+
+	if got := buf.FilterByKind("net"); len(got) != 2 {
+		t.Fatalf("FilterByKind(net) = %v, want 2 entries", got)
+	}
+
+	counts := buf.CountByKind()
+	if counts["net"] != 2 || counts["validation"] != 1 {
+		t.Fatalf("CountByKind() = %v, want net:2 validation:1", counts)
+	}
+
+	if _, ok := counts[nil]; ok {
+		t.Fatalf("CountByKind() should not count uncategorized entries, got %v", counts)
+	}
+}
+
+func TestFilterByKindUncomparableKindDoesNotPanic(t *testing.T) {
+	buf := NewErrorsBuffer()
+	buf.AddWithAttrs(errors.New("oops"), []string{"uncomparable"})
+
+	if got := buf.FilterByKind("net"); len(got) != 0 {
+		t.Fatalf("FilterByKind(net) = %v, want none", got)
+	}
+
+	if got := buf.CountByKind(); len(got) != 0 {
+		t.Fatalf("CountByKind() = %v, want empty, uncomparable kind should be skipped", got)
+	}
+}
@@ -20,6 +20,34 @@ type BufferedError struct {
 	// checked with [ShouldWarn] method and has additional
 	// stringer [Warning] method.
 	warnings []error
+
+	// captureStack is set by [WithStackTrace] and makes Add/Warn and
+	// their WithAttrs variants capture the caller's stack frames.
+	captureStack bool
+
+	// includeWarnings is set by [BufferedError.IncludeWarningsInUnwrap]
+	// and makes [BufferedError.Unwrap] also expose the warnings buffer.
+	includeWarnings bool
+
+	// maxErrors and maxWarnings bound the respective buffers when the
+	// receiver was created with [NewBoundedErrorsBuffer]. 0 means
+	// unbounded.
+	maxErrors, maxWarnings int
+	// policy decides what happens to new entries once a bounded buffer
+	// is full.
+	policy OverflowPolicy
+	// dropped counts entries discarded by policy.
+	dropped int
+	// occurrences counts, per error message, how many times an entry was
+	// offered to a buffer using [PolicyCoalesce] while that message was
+	// held in the buffer or stored by the offer.
+	occurrences map[string]int
+	// seenErrors and seenWarnings count every entry ever offered to the
+	// respective buffer, used by [PolicySample].
+	seenErrors, seenWarnings int
+
+	// sink is set by [WithSink] and is notified on every Add/Warn call.
+	sink Sink
 }
 
 // Error formats errors in the buffer into the string.
@@ -53,28 +81,140 @@ func bufToString(sl []error) string {
 	return string(b)
 }
 
-// Unwrap returns copy of the buffer errors.
+// Unwrap returns copy of the buffer errors, allowing [errors.Is] and
+// [errors.As] to traverse into them. Warnings are included only if
+// [BufferedError.IncludeWarningsInUnwrap] was enabled.
 func (buf *BufferedError) Unwrap() []error {
 	buf.Lock()
 	defer buf.Unlock()
 
-	return append([]error{}, buf.errors...)
+	out := append([]error{}, buf.errors...)
+
+	if buf.includeWarnings {
+		out = append(out, buf.warnings...)
+	}
+
+	return out
 }
 
 // Add adds given error to the errors buffer.
 func (buf *BufferedError) Add(err error) {
+	if sink := buf.addError(err, nil, nil); sink != nil {
+		sink.OnError(err)
+	}
+}
+
+// Warn adds given error to the warnings buffer.
+func (buf *BufferedError) Warn(err error) {
+	if sink := buf.addWarning(err, nil, nil); sink != nil {
+		sink.OnWarning(err)
+	}
+}
+
+// AddWithAttrs adds given error to the errors buffer tagging it with kind
+// and attrs so it can later be retrieved via [BufferedError.FilterByKind] or
+// counted via [BufferedError.CountByKind].
+func (buf *BufferedError) AddWithAttrs(err error, kind Kind, attrs ...Attr) {
+	if sink := buf.addError(err, kind, attrs); sink != nil {
+		sink.OnError(err)
+	}
+}
+
+// WarnWithAttrs adds given error to the warnings buffer tagging it with kind
+// and attrs so it can later be retrieved via [BufferedError.FilterByKind] or
+// counted via [BufferedError.CountByKind].
+func (buf *BufferedError) WarnWithAttrs(err error, kind Kind, attrs ...Attr) {
+	if sink := buf.addWarning(err, kind, attrs); sink != nil {
+		sink.OnWarning(err)
+	}
+}
+
+// addError stores err in the errors buffer and returns the sink to notify,
+// if any, once the lock below has been released. Add and AddWithAttrs call
+// this directly, at the same stack depth, so a captured stack trace starts
+// at the caller of whichever of the two was used.
+func (buf *BufferedError) addError(err error, kind Kind, attrs []Attr) Sink {
 	buf.Lock()
 	defer buf.Unlock()
 
-	buf.errors = append(buf.errors, err)
+	e := &entry{err: err, severity: SeverityError, kind: kind, attrs: attrs}
+
+	if buf.captureStack && !hasStack(err) {
+		e.frames = captureFrames(2)
+	}
+
+	if buf.maxErrors <= 0 {
+		buf.errors = append(buf.errors, e)
+	} else {
+		buf.seenErrors++
+		buf.errors = buf.appendBounded(buf.errors, e, buf.maxErrors, buf.seenErrors)
+	}
+
+	return buf.sink
 }
 
-// Warn adds given error to the warnings buffer.
-func (buf *BufferedError) Warn(err error) {
+// addWarning stores err in the warnings buffer and returns the sink to
+// notify, if any, once the lock below has been released. Warn and
+// WarnWithAttrs call this directly, at the same stack depth, so a captured
+// stack trace starts at the caller of whichever of the two was used.
+func (buf *BufferedError) addWarning(err error, kind Kind, attrs []Attr) Sink {
 	buf.Lock()
 	defer buf.Unlock()
 
-	buf.warnings = append(buf.warnings, err)
+	e := &entry{err: err, severity: SeverityWarning, kind: kind, attrs: attrs}
+
+	if buf.captureStack && !hasStack(err) {
+		e.frames = captureFrames(2)
+	}
+
+	if buf.maxWarnings <= 0 {
+		buf.warnings = append(buf.warnings, e)
+	} else {
+		buf.seenWarnings++
+		buf.warnings = buf.appendBounded(buf.warnings, e, buf.maxWarnings, buf.seenWarnings)
+	}
+
+	return buf.sink
+}
+
+// FilterByKind returns the underlying errors, collected from both the errors
+// and the warnings buffer, whose kind equals k. Useful for callers that
+// aggregate results produced by concurrent workers.
+func (buf *BufferedError) FilterByKind(k Kind) []error {
+	buf.Lock()
+	defer buf.Unlock()
+
+	var out []error
+
+	for _, sl := range [][]error{buf.errors, buf.warnings} {
+		for _, err := range sl {
+			if e, ok := err.(*entry); ok && kindEqual(e.kind, k) {
+				out = append(out, e.err)
+			}
+		}
+	}
+
+	return out
+}
+
+// CountByKind returns the number of entries, collected from both the errors
+// and the warnings buffer, grouped by kind. Entries added without a kind are
+// not included.
+func (buf *BufferedError) CountByKind() map[Kind]int {
+	buf.Lock()
+	defer buf.Unlock()
+
+	counts := make(map[Kind]int)
+
+	for _, sl := range [][]error{buf.errors, buf.warnings} {
+		for _, err := range sl {
+			if e, ok := err.(*entry); ok && e.kind != nil {
+				countKind(counts, e.kind)
+			}
+		}
+	}
+
+	return counts
 }
 
 // Err returns nil if error buffer is empty.
@@ -97,9 +237,16 @@ func (buf *BufferedError) ShouldWarn() bool {
 	return len(buf.warnings) != 0
 }
 
-// NewErrorsBuffer creates empty [BufferedError].
-func NewErrorsBuffer() *BufferedError {
-	return &BufferedError{errors: make([]error, 0), warnings: make([]error, 0)}
+// NewErrorsBuffer creates empty [BufferedError], applying the given opts,
+// e.g. [WithStackTrace].
+func NewErrorsBuffer(opts ...Option) *BufferedError {
+	buf := &BufferedError{errors: make([]error, 0), warnings: make([]error, 0)}
+
+	for _, opt := range opts {
+		opt(buf)
+	}
+
+	return buf
 }
 
 // NewBufferFromError creates new [BufferedError] from the given error.
@@ -0,0 +1,28 @@
+// Package errbufpb contains the Go types mirroring errbuf.proto, used to
+// transport a [errbuf.BufferedError] across a process boundary. Marshal and
+// Unmarshal, implemented in wire.go, encode to the proto3 wire format
+// described by errbuf.proto, so the bytes are interoperable with any other
+// protobuf implementation reading that schema, without depending on protoc
+// or the protobuf runtime.
+package errbufpb
+
+// Attr is the wire representation of errbuf.Attr.
+type Attr struct {
+	Key   string
+	Value string
+}
+
+// Entry is the wire representation of a single buffered error or warning.
+type Entry struct {
+	Msg      string
+	Kind     string
+	Attrs    []Attr
+	Stack    []string
+	Sentinel string
+}
+
+// Buffer is the wire representation of a [errbuf.BufferedError].
+type Buffer struct {
+	Errors   []Entry
+	Warnings []Entry
+}
@@ -0,0 +1,211 @@
+package errbufpb
+
+import (
+	"errors"
+	"fmt"
+)
+
+// This file implements the proto3 wire format (varint tags, length-delimited
+// strings and embedded messages) for the messages declared in errbuf.proto,
+// by hand, so that errbufpb has no dependency on the protobuf runtime or on
+// protoc. Field numbers below must stay in sync with errbuf.proto. As with
+// generated code, zero-value fields are omitted on encode.
+
+const wireLenDelimited = 2
+
+// appendVarint appends v to b using protobuf's base-128 varint encoding.
+func appendVarint(b []byte, v uint64) []byte {
+	for v >= 0x80 {
+		b = append(b, byte(v)|0x80)
+		v >>= 7
+	}
+
+	return append(b, byte(v))
+}
+
+// consumeVarint decodes a varint from the start of b, returning the value
+// and the number of bytes it occupied.
+func consumeVarint(b []byte) (v uint64, n int, ok bool) {
+	var shift uint
+
+	for i, c := range b {
+		if shift >= 64 {
+			return 0, 0, false
+		}
+
+		v |= uint64(c&0x7f) << shift
+
+		if c < 0x80 {
+			return v, i + 1, true
+		}
+
+		shift += 7
+	}
+
+	return 0, 0, false
+}
+
+// appendLenDelimited appends a length-delimited field (string, bytes or
+// embedded message) tagged with field, skipping it entirely when data is
+// empty, matching proto3's default-value-is-omitted behavior.
+func appendLenDelimited(b []byte, field int, data []byte) []byte {
+	if len(data) == 0 {
+		return b
+	}
+
+	b = appendVarint(b, uint64(field)<<3|wireLenDelimited)
+	b = appendVarint(b, uint64(len(data)))
+
+	return append(b, data...)
+}
+
+// appendString is appendLenDelimited for a string field.
+func appendString(b []byte, field int, s string) []byte {
+	if s == "" {
+		return b
+	}
+
+	return appendLenDelimited(b, field, []byte(s))
+}
+
+// eachField walks the length-delimited fields encoded in data, calling fn
+// with each field number and its raw payload. Varint-typed fields are not
+// needed by any message in errbuf.proto and are rejected.
+func eachField(data []byte, fn func(field int, raw []byte) error) error {
+	for len(data) > 0 {
+		tag, n, ok := consumeVarint(data)
+		if !ok {
+			return errors.New("errbufpb: invalid field tag")
+		}
+
+		data = data[n:]
+
+		field, wireType := int(tag>>3), int(tag&0x7)
+		if wireType != wireLenDelimited {
+			return fmt.Errorf("errbufpb: unsupported wire type %d for field %d", wireType, field)
+		}
+
+		l, n, ok := consumeVarint(data)
+		if !ok {
+			return errors.New("errbufpb: invalid field length")
+		}
+
+		data = data[n:]
+
+		if uint64(len(data)) < l {
+			return errors.New("errbufpb: truncated field")
+		}
+
+		if err := fn(field, data[:l]); err != nil {
+			return err
+		}
+
+		data = data[l:]
+	}
+
+	return nil
+}
+
+// marshalAppend appends the wire encoding of a to b.
+func (a *Attr) marshalAppend(b []byte) []byte {
+	b = appendString(b, 1, a.Key)
+	b = appendString(b, 2, a.Value)
+
+	return b
+}
+
+// Unmarshal decodes data, as produced by [Attr.marshalAppend], into a.
+func (a *Attr) Unmarshal(data []byte) error {
+	return eachField(data, func(field int, raw []byte) error {
+		switch field {
+		case 1:
+			a.Key = string(raw)
+		case 2:
+			a.Value = string(raw)
+		}
+
+		return nil
+	})
+}
+
+// marshalAppend appends the wire encoding of e to b.
+func (e *Entry) marshalAppend(b []byte) []byte {
+	b = appendString(b, 1, e.Msg)
+	b = appendString(b, 2, e.Kind)
+
+	for _, a := range e.Attrs {
+		b = appendLenDelimited(b, 3, a.marshalAppend(nil))
+	}
+
+	for _, s := range e.Stack {
+		b = appendString(b, 4, s)
+	}
+
+	b = appendString(b, 5, e.Sentinel)
+
+	return b
+}
+
+// Unmarshal decodes data, as produced by [Entry.marshalAppend], into e.
+func (e *Entry) Unmarshal(data []byte) error {
+	return eachField(data, func(field int, raw []byte) error {
+		switch field {
+		case 1:
+			e.Msg = string(raw)
+		case 2:
+			e.Kind = string(raw)
+		case 3:
+			var a Attr
+			if err := a.Unmarshal(raw); err != nil {
+				return err
+			}
+
+			e.Attrs = append(e.Attrs, a)
+		case 4:
+			e.Stack = append(e.Stack, string(raw))
+		case 5:
+			e.Sentinel = string(raw)
+		}
+
+		return nil
+	})
+}
+
+// Marshal encodes b into the proto3 wire format described by errbuf.proto.
+func (b *Buffer) Marshal() ([]byte, error) {
+	var out []byte
+
+	for _, e := range b.Errors {
+		out = appendLenDelimited(out, 1, e.marshalAppend(nil))
+	}
+
+	for _, e := range b.Warnings {
+		out = appendLenDelimited(out, 2, e.marshalAppend(nil))
+	}
+
+	return out, nil
+}
+
+// Unmarshal decodes data, as produced by [Buffer.Marshal], into b.
+func (b *Buffer) Unmarshal(data []byte) error {
+	return eachField(data, func(field int, raw []byte) error {
+		switch field {
+		case 1:
+			var e Entry
+			if err := e.Unmarshal(raw); err != nil {
+				return err
+			}
+
+			b.Errors = append(b.Errors, e)
+		case 2:
+			var e Entry
+			if err := e.Unmarshal(raw); err != nil {
+				return err
+			}
+
+			b.Warnings = append(b.Warnings, e)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,62 @@
+package errbufpb
+
+import "testing"
+
+func TestBufferMarshalUnmarshalRoundTrip(t *testing.T) {
+	want := Buffer{
+		Errors: []Entry{
+			{
+				Msg:      "net down",
+				Kind:     "net",
+				Attrs:    []Attr{{Key: "host", Value: "a"}},
+				Stack:    []string{"main.main main.go:10"},
+				Sentinel: "sentinel-id",
+			},
+		},
+		Warnings: []Entry{
+			{Msg: "slow"},
+		},
+	}
+
+	data, err := want.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var got Buffer
+	if err := got.Unmarshal(data); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if len(got.Errors) != 1 {
+		t.Fatalf("Errors = %+v, want 1 entry", got.Errors)
+	}
+
+	gotEntry, wantEntry := got.Errors[0], want.Errors[0]
+	if gotEntry.Msg != wantEntry.Msg || gotEntry.Kind != wantEntry.Kind || gotEntry.Sentinel != wantEntry.Sentinel {
+		t.Fatalf("Errors[0] = %+v, want %+v", gotEntry, wantEntry)
+	}
+
+	if len(gotEntry.Attrs) != 1 || gotEntry.Attrs[0] != wantEntry.Attrs[0] {
+		t.Fatalf("Attrs = %+v, want %+v", gotEntry.Attrs, wantEntry.Attrs)
+	}
+
+	if len(gotEntry.Stack) != 1 || gotEntry.Stack[0] != wantEntry.Stack[0] {
+		t.Fatalf("Stack = %+v, want %+v", gotEntry.Stack, wantEntry.Stack)
+	}
+
+	if len(got.Warnings) != 1 || got.Warnings[0].Msg != "slow" {
+		t.Fatalf("Warnings = %+v, want [{Msg: slow}]", got.Warnings)
+	}
+}
+
+func TestEmptyFieldsAreOmitted(t *testing.T) {
+	data, err := (&Buffer{}).Marshal()
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	if len(data) != 0 {
+		t.Fatalf("Marshal() of an empty Buffer = %x, want empty", data)
+	}
+}
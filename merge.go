@@ -0,0 +1,86 @@
+package errbuf
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// lockOrder returns a and b ordered by address so two buffers are always
+// locked in the same order, regardless of which is the receiver, preventing
+// deadlocks when two goroutines merge the same pair of buffers concurrently
+// in opposite directions.
+func lockOrder(a, b *BufferedError) (*BufferedError, *BufferedError) {
+	if uintptr(unsafe.Pointer(a)) <= uintptr(unsafe.Pointer(b)) {
+		return a, b
+	}
+
+	return b, a
+}
+
+// Merge appends the errors and warnings of other into buf. It is a no-op if
+// other is nil or the same buffer as buf.
+func (buf *BufferedError) Merge(other *BufferedError) {
+	if other == nil || other == buf {
+		return
+	}
+
+	first, second := lockOrder(buf, other)
+
+	first.Lock()
+	defer first.Unlock()
+
+	second.Lock()
+	defer second.Unlock()
+
+	buf.errors = append(buf.errors, other.errors...)
+	buf.warnings = append(buf.warnings, other.warnings...)
+}
+
+// rewrap wraps the error carried by original with outer, preserving kind,
+// attrs and captured frames when original is a structured entry.
+func rewrap(outer, original error) error {
+	if e, ok := original.(*entry); ok {
+		wrapped := *e
+		wrapped.err = fmt.Errorf("%w: %w", outer, e.err)
+
+		return &wrapped
+	}
+
+	return fmt.Errorf("%w: %w", outer, original)
+}
+
+// Wrap prepends err to every entry currently in the buffer, both errors and
+// warnings, and returns buf for chaining.
+func (buf *BufferedError) Wrap(err error) *BufferedError {
+	buf.Lock()
+	defer buf.Unlock()
+
+	for i, original := range buf.errors {
+		buf.errors[i] = rewrap(err, original)
+	}
+
+	for i, original := range buf.warnings {
+		buf.warnings[i] = rewrap(err, original)
+	}
+
+	return buf
+}
+
+// IncludeWarningsInUnwrap toggles whether [BufferedError.Unwrap] also
+// exposes the warnings buffer, so [errors.Is]/[errors.As] can match against
+// warnings too.
+func (buf *BufferedError) IncludeWarningsInUnwrap(include bool) {
+	buf.Lock()
+	defer buf.Unlock()
+
+	buf.includeWarnings = include
+}
+
+// Split returns copies of the errors and warnings buffers, for callers that
+// want to re-route them to other sinks.
+func (buf *BufferedError) Split() (errs, warns []error) {
+	buf.Lock()
+	defer buf.Unlock()
+
+	return append([]error{}, buf.errors...), append([]error{}, buf.warnings...)
+}
@@ -0,0 +1,72 @@
+package errbuf
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	a := NewErrorsBuffer()
+	a.Add(errors.New("a-err"))
+	a.Warn(errors.New("a-warn"))
+
+	b := NewErrorsBuffer()
+	b.Add(errors.New("b-err"))
+	b.Warn(errors.New("b-warn"))
+
+	a.Merge(b)
+
+	errs, warns := a.Split()
+	if len(errs) != 2 || len(warns) != 2 {
+		t.Fatalf("after Merge: errs=%v warns=%v, want 2 and 2", errs, warns)
+	}
+}
+
+func TestMergeSelfIsNoop(t *testing.T) {
+	a := NewErrorsBuffer()
+	a.Add(errors.New("only"))
+
+	a.Merge(a)
+
+	errs, _ := a.Split()
+	if len(errs) != 1 {
+		t.Fatalf("Merge(self) should be a no-op, got %v", errs)
+	}
+}
+
+func TestWrapPrependsOuterAndKeepsIsMatching(t *testing.T) {
+	sentinel := errors.New("not found")
+
+	buf := NewErrorsBuffer()
+	buf.Add(sentinel)
+
+	outer := errors.New("request failed")
+	buf.Wrap(outer)
+
+	wrapped := buf.Unwrap()[0]
+
+	if !errors.Is(wrapped, outer) {
+		t.Fatalf("wrapped error should match outer via errors.Is")
+	}
+
+	if !errors.Is(wrapped, sentinel) {
+		t.Fatalf("wrapped error should still match the original sentinel via errors.Is")
+	}
+}
+
+func TestIncludeWarningsInUnwrap(t *testing.T) {
+	warn := errors.New("warn")
+
+	buf := NewErrorsBuffer()
+	buf.Warn(warn)
+
+	if errors.Is(buf, warn) {
+		t.Fatalf("warnings should not be matched before IncludeWarningsInUnwrap(true)")
+	}
+
+	buf.IncludeWarningsInUnwrap(true)
+
+	if !errors.Is(buf, warn) {
+		t.Fatalf("warnings should be matched after IncludeWarningsInUnwrap(true)")
+	}
+}
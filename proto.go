@@ -0,0 +1,97 @@
+package errbuf
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/emar-kar/errbuf/errbufpb"
+)
+
+// toProtoEntry converts err, as stored in the buffer, into its protobuf wire
+// form.
+func toProtoEntry(err error) errbufpb.Entry {
+	pe := errbufpb.Entry{Msg: err.Error()}
+
+	if e, ok := err.(*entry); ok {
+		if e.kind != nil {
+			pe.Kind = fmt.Sprint(e.kind)
+		}
+
+		for _, a := range e.attrs {
+			pe.Attrs = append(pe.Attrs, errbufpb.Attr{Key: a.Key, Value: fmt.Sprint(a.Value)})
+		}
+
+		for _, fr := range e.frames {
+			pe.Stack = append(pe.Stack, fmt.Sprintf("%s %s:%d", fr.Function, fr.File, fr.Line))
+		}
+	}
+
+	if id, ok := sentinelID(err); ok {
+		pe.Sentinel = id
+	}
+
+	return pe
+}
+
+// fromProtoEntry is the inverse of [toProtoEntry], for severity (errors or
+// warnings). The kind comes back as the string it was encoded as, so a kind
+// that was not a string does not compare equal to its original value.
+// Captured stack frames cannot be reconstructed from their formatted wire
+// strings and are dropped.
+func fromProtoEntry(pe errbufpb.Entry, severity Severity) error {
+	base, ok := sentinelByID(pe.Sentinel)
+	if pe.Sentinel == "" || !ok {
+		base = errors.New(pe.Msg)
+	}
+
+	if pe.Kind == "" && len(pe.Attrs) == 0 {
+		return base
+	}
+
+	e := &entry{err: base, severity: severity, kind: pe.Kind}
+
+	for _, a := range pe.Attrs {
+		e.attrs = append(e.attrs, Attr{Key: a.Key, Value: a.Value})
+	}
+
+	return e
+}
+
+// ToProto converts the buffer into an [errbufpb.Buffer], the type mirroring
+// errbuf.proto, so it can be encoded with [errbufpb.Buffer.Marshal] and sent
+// over gRPC or any other protobuf transport.
+func (buf *BufferedError) ToProto() *errbufpb.Buffer {
+	buf.Lock()
+	defer buf.Unlock()
+
+	pb := &errbufpb.Buffer{
+		Errors:   make([]errbufpb.Entry, 0, len(buf.errors)),
+		Warnings: make([]errbufpb.Entry, 0, len(buf.warnings)),
+	}
+
+	for _, err := range buf.errors {
+		pb.Errors = append(pb.Errors, toProtoEntry(err))
+	}
+
+	for _, err := range buf.warnings {
+		pb.Warnings = append(pb.Warnings, toProtoEntry(err))
+	}
+
+	return pb
+}
+
+// FromProto builds a [BufferedError] from its protobuf wire form, as
+// produced by [BufferedError.ToProto].
+func FromProto(pb *errbufpb.Buffer) *BufferedError {
+	buf := NewErrorsBuffer()
+
+	for _, pe := range pb.Errors {
+		buf.errors = append(buf.errors, fromProtoEntry(pe, SeverityError))
+	}
+
+	for _, pe := range pb.Warnings {
+		buf.warnings = append(buf.warnings, fromProtoEntry(pe, SeverityWarning))
+	}
+
+	return buf
+}
@@ -0,0 +1,34 @@
+package errbuf
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/emar-kar/errbuf/errbufpb"
+)
+
+func TestProtoRoundTripPreservesKindAndAttrs(t *testing.T) {
+	buf := NewErrorsBuffer()
+	buf.AddWithAttrs(errors.New("net down"), "net", Attr{Key: "host", Value: "a"})
+	buf.Warn(errors.New("slow"))
+
+	data, err := buf.ToProto().Marshal()
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var pb errbufpb.Buffer
+	if err := pb.Unmarshal(data); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	got := FromProto(&pb)
+
+	if filtered := got.FilterByKind("net"); len(filtered) != 1 || filtered[0].Error() != "net down" {
+		t.Fatalf("FilterByKind(net) after proto round trip = %v, want [net down]", filtered)
+	}
+
+	if got.Warning() != "slow" {
+		t.Fatalf("Warning() after proto round trip = %q, want slow", got.Warning())
+	}
+}
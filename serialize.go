@@ -0,0 +1,180 @@
+package errbuf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// sentinelMu guards the sentinel registry below.
+var sentinelMu sync.RWMutex
+
+// sentinels maps an id, registered via [RegisterSentinel], to the error it
+// stands for. It is used by [BufferedError.UnmarshalJSON] to reconstruct
+// errors that satisfy [errors.Is] on the receiving side of a marshal/
+// unmarshal round trip.
+var sentinels = make(map[string]error)
+
+// RegisterSentinel associates err with id so that a [BufferedError] produced
+// by [BufferedError.UnmarshalJSON] on another process can match it back with
+// [errors.Is]. Both sides of the transport are expected to register the same
+// sentinels under the same ids.
+func RegisterSentinel(err error, id string) {
+	sentinelMu.Lock()
+	defer sentinelMu.Unlock()
+
+	sentinels[id] = err
+}
+
+// sentinelID returns the id of a registered sentinel that err satisfies via
+// [errors.Is], if any. If err satisfies several, which id is returned is not
+// defined.
+func sentinelID(err error) (string, bool) {
+	sentinelMu.RLock()
+	defer sentinelMu.RUnlock()
+
+	for id, sentinel := range sentinels {
+		if errors.Is(err, sentinel) {
+			return id, true
+		}
+	}
+
+	return "", false
+}
+
+// sentinelByID returns the error registered under id, if any.
+func sentinelByID(id string) (error, bool) {
+	sentinelMu.RLock()
+	defer sentinelMu.RUnlock()
+
+	err, ok := sentinels[id]
+
+	return err, ok
+}
+
+// attrJSON is the wire representation of [Attr].
+type attrJSON struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
+}
+
+// entryJSON is the wire representation of a single buffered entry.
+type entryJSON struct {
+	Msg      string     `json:"msg"`
+	Kind     string     `json:"kind,omitempty"`
+	Attrs    []attrJSON `json:"attrs,omitempty"`
+	Stack    []string   `json:"stack,omitempty"`
+	Sentinel string     `json:"sentinel,omitempty"`
+}
+
+// bufferJSON is the wire representation of a [BufferedError].
+type bufferJSON struct {
+	Errors   []entryJSON `json:"errors"`
+	Warnings []entryJSON `json:"warnings"`
+}
+
+// toEntryJSON converts err, as stored in the buffer, into its wire form.
+func toEntryJSON(err error) entryJSON {
+	ej := entryJSON{Msg: err.Error()}
+
+	if e, ok := err.(*entry); ok {
+		if e.kind != nil {
+			ej.Kind = fmt.Sprint(e.kind)
+		}
+
+		for _, a := range e.attrs {
+			ej.Attrs = append(ej.Attrs, attrJSON{Key: a.Key, Value: a.Value})
+		}
+
+		for _, fr := range e.frames {
+			ej.Stack = append(ej.Stack, fmt.Sprintf("%s %s:%d", fr.Function, fr.File, fr.Line))
+		}
+	}
+
+	if id, ok := sentinelID(err); ok {
+		ej.Sentinel = id
+	}
+
+	return ej
+}
+
+// fromEntryJSON reconstructs an error from its wire form, for severity
+// (errors or warnings). If ej carries a sentinel id that was registered via
+// [RegisterSentinel], the registered error is used as the base so that
+// [errors.Is] keeps working after the round trip. If ej also carries a kind
+// or attrs, the result is wrapped in an *entry so [BufferedError.FilterByKind]
+// and [BufferedError.CountByKind] keep working too. The kind comes back as
+// the string it was encoded as, so a kind that was not a string does not
+// compare equal to its original value. Captured stack frames
+// cannot be reconstructed from their formatted wire strings and are
+// dropped on unmarshal.
+func fromEntryJSON(ej entryJSON, severity Severity) error {
+	base, ok := sentinelByID(ej.Sentinel)
+	if ej.Sentinel == "" || !ok {
+		base = errors.New(ej.Msg)
+	}
+
+	if ej.Kind == "" && len(ej.Attrs) == 0 {
+		return base
+	}
+
+	e := &entry{err: base, severity: severity, kind: ej.Kind}
+
+	for _, a := range ej.Attrs {
+		e.attrs = append(e.attrs, Attr{Key: a.Key, Value: a.Value})
+	}
+
+	return e
+}
+
+// MarshalJSON encodes the buffer, including attributes, kinds, captured
+// stack frames and registered sentinels, so it can be shipped to another
+// process and reconstructed with [BufferedError.UnmarshalJSON].
+func (buf *BufferedError) MarshalJSON() ([]byte, error) {
+	buf.Lock()
+	defer buf.Unlock()
+
+	bj := bufferJSON{
+		Errors:   make([]entryJSON, 0, len(buf.errors)),
+		Warnings: make([]entryJSON, 0, len(buf.warnings)),
+	}
+
+	for _, err := range buf.errors {
+		bj.Errors = append(bj.Errors, toEntryJSON(err))
+	}
+
+	for _, err := range buf.warnings {
+		bj.Warnings = append(bj.Warnings, toEntryJSON(err))
+	}
+
+	return json.Marshal(bj)
+}
+
+// UnmarshalJSON decodes a buffer previously produced by
+// [BufferedError.MarshalJSON], replacing the receiver's contents.
+func (buf *BufferedError) UnmarshalJSON(data []byte) error {
+	var bj bufferJSON
+
+	if err := json.Unmarshal(data, &bj); err != nil {
+		return err
+	}
+
+	errs := make([]error, 0, len(bj.Errors))
+	for _, ej := range bj.Errors {
+		errs = append(errs, fromEntryJSON(ej, SeverityError))
+	}
+
+	warns := make([]error, 0, len(bj.Warnings))
+	for _, ej := range bj.Warnings {
+		warns = append(warns, fromEntryJSON(ej, SeverityWarning))
+	}
+
+	buf.Lock()
+	defer buf.Unlock()
+
+	buf.errors = errs
+	buf.warnings = warns
+
+	return nil
+}
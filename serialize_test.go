@@ -0,0 +1,53 @@
+package errbuf
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestJSONRoundTripPreservesKindAndAttrs(t *testing.T) {
+	buf := NewErrorsBuffer()
+	buf.AddWithAttrs(errors.New("net down"), "net", Attr{Key: "host", Value: "a"})
+	buf.Warn(errors.New("slow"))
+
+	data, err := json.Marshal(buf)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	got := NewErrorsBuffer()
+	if err := json.Unmarshal(data, got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if filtered := got.FilterByKind("net"); len(filtered) != 1 || filtered[0].Error() != "net down" {
+		t.Fatalf("FilterByKind(net) after round trip = %v, want [net down]", filtered)
+	}
+
+	if got.Warning() != "slow" {
+		t.Fatalf("Warning() after round trip = %q, want slow", got.Warning())
+	}
+}
+
+func TestJSONRoundTripPreservesSentinel(t *testing.T) {
+	sentinel := errors.New("not found")
+	RegisterSentinel(sentinel, "test.not-found")
+
+	buf := NewErrorsBuffer()
+	buf.Add(sentinel)
+
+	data, err := json.Marshal(buf)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	got := NewErrorsBuffer()
+	if err := json.Unmarshal(data, got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if !errors.Is(got.Unwrap()[0], sentinel) {
+		t.Fatalf("reconstructed error should match the registered sentinel via errors.Is")
+	}
+}
@@ -0,0 +1,91 @@
+package errbuf
+
+import "log/slog"
+
+// Sink receives a callback for every error or warning added to a
+// [BufferedError] created with [WithSink], allowing integration with
+// loggers or other external handlers.
+type Sink interface {
+	OnError(err error)
+	OnWarning(err error)
+}
+
+// WithSink makes the buffer notify s on every [BufferedError.Add]/
+// [BufferedError.Warn] call, in addition to storing the entry. The
+// callback runs after the entry is appended and after the buffer's lock
+// has been released, so s is free to call back into the same buffer. For a
+// buffer created with [NewBoundedErrorsBuffer], s is notified even of an
+// entry that the overflow policy discarded.
+func WithSink(s Sink) Option {
+	return func(buf *BufferedError) {
+		buf.sink = s
+	}
+}
+
+// slogSink adapts a [slog.Logger] into a [Sink].
+type slogSink struct {
+	logger *slog.Logger
+}
+
+// SlogSink reports errors and warnings to logger, at Error and Warn level
+// respectively.
+func SlogSink(logger *slog.Logger) Sink {
+	return &slogSink{logger: logger}
+}
+
+// OnError implements [Sink].
+func (s *slogSink) OnError(err error) {
+	s.logger.Error(err.Error())
+}
+
+// OnWarning implements [Sink].
+func (s *slogSink) OnWarning(err error) {
+	s.logger.Warn(err.Error())
+}
+
+// channelSink adapts a send-only error channel into a [Sink].
+type channelSink struct {
+	ch chan<- error
+}
+
+// ChannelSink reports both errors and warnings by sending them to ch. It
+// blocks if ch is unbuffered or full, so callers typically want a buffered
+// channel drained by a dedicated goroutine.
+func ChannelSink(ch chan<- error) Sink {
+	return &channelSink{ch: ch}
+}
+
+// OnError implements [Sink].
+func (s *channelSink) OnError(err error) {
+	s.ch <- err
+}
+
+// OnWarning implements [Sink].
+func (s *channelSink) OnWarning(err error) {
+	s.ch <- err
+}
+
+// multiSink fans a callback out to every sink it wraps.
+type multiSink struct {
+	sinks []Sink
+}
+
+// MultiSink combines several sinks into one, calling each of them in order
+// for every callback.
+func MultiSink(sinks ...Sink) Sink {
+	return &multiSink{sinks: sinks}
+}
+
+// OnError implements [Sink].
+func (s *multiSink) OnError(err error) {
+	for _, sink := range s.sinks {
+		sink.OnError(err)
+	}
+}
+
+// OnWarning implements [Sink].
+func (s *multiSink) OnWarning(err error) {
+	for _, sink := range s.sinks {
+		sink.OnWarning(err)
+	}
+}
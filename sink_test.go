@@ -0,0 +1,108 @@
+package errbuf
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+)
+
+// reentrantSink calls back into the buffer it watches, to verify the lock
+// is released before the sink callback runs.
+type reentrantSink struct {
+	buf  *BufferedError
+	seen []string
+}
+
+func (s *reentrantSink) OnError(err error) {
+	s.seen = append(s.seen, buferr(s.buf))
+}
+
+func (s *reentrantSink) OnWarning(error) {}
+
+func buferr(buf *BufferedError) string {
+	return buf.Error()
+}
+
+func TestSinkRunsWithoutHoldingTheLock(t *testing.T) {
+	sink := &reentrantSink{}
+	buf := NewErrorsBuffer(WithSink(sink))
+	sink.buf = buf
+
+	done := make(chan struct{})
+
+	go func() {
+		buf.Add(errors.New("boom"))
+		close(done)
+	}()
+
+	<-done
+
+	if len(sink.seen) != 1 || sink.seen[0] != "boom" {
+		t.Fatalf("sink.seen = %v, want [boom] (sink callback should see the buffer it was notified about)", sink.seen)
+	}
+}
+
+func TestMultiSinkFansOut(t *testing.T) {
+	var a, b []error
+
+	collect := func(dst *[]error) Sink {
+		return &funcSink{onError: func(err error) { *dst = append(*dst, err) }}
+	}
+
+	buf := NewErrorsBuffer(WithSink(MultiSink(collect(&a), collect(&b))))
+
+	err := errors.New("boom")
+	buf.Add(err)
+
+	if len(a) != 1 || len(b) != 1 {
+		t.Fatalf("MultiSink should fan out to every sink, got a=%v b=%v", a, b)
+	}
+}
+
+func TestChannelSink(t *testing.T) {
+	ch := make(chan error, 1)
+	buf := NewErrorsBuffer(WithSink(ChannelSink(ch)))
+
+	err := errors.New("boom")
+	buf.Add(err)
+
+	select {
+	case got := <-ch:
+		if got != err {
+			t.Fatalf("ChannelSink delivered %v, want %v", got, err)
+		}
+	default:
+		t.Fatalf("ChannelSink should have sent the error to the channel")
+	}
+}
+
+func TestSlogSink(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	eb := NewErrorsBuffer(WithSink(SlogSink(logger)))
+	eb.Warn(errors.New("heads up"))
+
+	if out := buf.String(); out == "" {
+		t.Fatalf("SlogSink should have written a log line, got empty output")
+	}
+}
+
+// funcSink adapts plain functions into a [Sink], for tests only.
+type funcSink struct {
+	onError   func(error)
+	onWarning func(error)
+}
+
+func (s *funcSink) OnError(err error) {
+	if s.onError != nil {
+		s.onError(err)
+	}
+}
+
+func (s *funcSink) OnWarning(err error) {
+	if s.onWarning != nil {
+		s.onWarning(err)
+	}
+}
@@ -0,0 +1,115 @@
+package errbuf
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// maxStackDepth bounds how many PC frames are captured per entry.
+const maxStackDepth = 64
+
+// Option configures a [BufferedError] created via [NewErrorsBuffer].
+type Option func(*BufferedError)
+
+// WithStackTrace makes every subsequent [BufferedError.Add]/
+// [BufferedError.Warn] call (and their WithAttrs variants) capture the
+// caller's stack frames, unless the incoming error already carries one.
+func WithStackTrace() Option {
+	return func(buf *BufferedError) {
+		buf.captureStack = true
+	}
+}
+
+// captureFrames walks the caller's stack, skipping skip frames on top of
+// runtime.Callers and captureFrames itself.
+func captureFrames(skip int) []runtime.Frame {
+	pcs := make([]uintptr, maxStackDepth)
+
+	n := runtime.Callers(skip+2, pcs)
+	if n == 0 {
+		return nil
+	}
+
+	iter := runtime.CallersFrames(pcs[:n])
+	frames := make([]runtime.Frame, 0, n)
+
+	for {
+		frame, more := iter.Next()
+		frames = append(frames, frame)
+
+		if !more {
+			break
+		}
+	}
+
+	return frames
+}
+
+// hasStack reports whether err already carries captured frames, so nested
+// buffers do not double-capture when an inner [BufferedError] is wrapped
+// into an outer one.
+func hasStack(err error) bool {
+	var e *entry
+
+	return errors.As(err, &e) && len(e.frames) > 0
+}
+
+// StackFrames returns the stack frames captured for err, if the buffer that
+// produced it was created with [WithStackTrace] and err does not already
+// carry frames captured elsewhere.
+func StackFrames(err error) []runtime.Frame {
+	var e *entry
+	if errors.As(err, &e) {
+		return e.frames
+	}
+
+	return nil
+}
+
+// Format implements [fmt.Formatter]. The %+v verb includes captured stack
+// frames for every entry; all other verbs fall back to [BufferedError.Error].
+func (buf *BufferedError) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('+') {
+		fmt.Fprint(f, buf.verboseString())
+		return
+	}
+
+	fmt.Fprint(f, buf.Error())
+}
+
+// verboseString renders errors and warnings together with any captured
+// stack frames.
+func (buf *BufferedError) verboseString() string {
+	buf.Lock()
+	defer buf.Unlock()
+
+	var b strings.Builder
+
+	writeEntries := func(label string, sl []error) {
+		if len(sl) == 0 {
+			return
+		}
+
+		fmt.Fprintf(&b, "%s:\n", label)
+
+		for _, err := range sl {
+			fmt.Fprintf(&b, "%s\n", err.Error())
+
+			e, ok := err.(*entry)
+			if !ok {
+				continue
+			}
+
+			for _, fr := range e.frames {
+				fmt.Fprintf(&b, "\t%s\n\t\t%s:%d\n", fr.Function, fr.File, fr.Line)
+			}
+		}
+	}
+
+	writeEntries("errors", buf.errors)
+	writeEntries("warnings", buf.warnings)
+
+	return strings.TrimRight(b.String(), "\n")
+}
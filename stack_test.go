@@ -0,0 +1,68 @@
+package errbuf
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func viaAdd(buf *BufferedError, err error) {
+	buf.Add(err)
+}
+
+func viaAddWithAttrs(buf *BufferedError, err error) {
+	buf.AddWithAttrs(err, "kind")
+}
+
+func TestWithStackTraceCapturesCallerFrame(t *testing.T) {
+	buf := NewErrorsBuffer(WithStackTrace())
+
+	errAdd := errors.New("via add")
+	errAttrs := errors.New("via add with attrs")
+
+	viaAdd(buf, errAdd)
+	viaAddWithAttrs(buf, errAttrs)
+
+	framesAdd := StackFrames(buf.Unwrap()[0])
+	if len(framesAdd) == 0 || !strings.HasSuffix(framesAdd[0].Function, "viaAdd") {
+		t.Fatalf("Add: frame 0 = %+v, want viaAdd", framesAdd)
+	}
+
+	framesAttrs := StackFrames(buf.Unwrap()[1])
+	if len(framesAttrs) == 0 || !strings.HasSuffix(framesAttrs[0].Function, "viaAddWithAttrs") {
+		t.Fatalf("AddWithAttrs: frame 0 = %+v, want viaAddWithAttrs", framesAttrs)
+	}
+}
+
+func TestWithStackTraceSkipsAlreadyCapturedError(t *testing.T) {
+	inner := NewErrorsBuffer(WithStackTrace())
+	inner.Add(errors.New("inner"))
+
+	innerEntry, ok := inner.errors[0].(*entry)
+	if !ok || len(innerEntry.frames) == 0 {
+		t.Fatalf("inner entry should have captured frames, got %#v", inner.errors[0])
+	}
+
+	outer := NewErrorsBuffer(WithStackTrace())
+	outer.Add(innerEntry)
+
+	outerEntry, ok := outer.errors[0].(*entry)
+	if !ok {
+		t.Fatalf("outer entry has unexpected type %#v", outer.errors[0])
+	}
+
+	if len(outerEntry.frames) != 0 {
+		t.Fatalf("outer entry should not recapture frames for an error that already has its own, got %+v", outerEntry.frames)
+	}
+}
+
+func TestFormatVerbose(t *testing.T) {
+	buf := NewErrorsBuffer(WithStackTrace())
+	buf.Add(errors.New("boom"))
+
+	out := fmt.Sprintf("%+v", buf)
+	if !strings.Contains(out, "boom") || !strings.Contains(out, "errors:") {
+		t.Fatalf("%%+v output = %q, want it to mention the error and a section header", out)
+	}
+}